@@ -0,0 +1,74 @@
+package gomavlib
+
+import (
+	"math/rand"
+	"time"
+)
+
+// default backoff parameters, mirroring the defaults used by grpc-go.
+const (
+	backoffDefaultBaseDelay  = 1 * time.Second
+	backoffDefaultMaxDelay   = 120 * time.Second
+	backoffDefaultMultiplier = 1.6
+	backoffDefaultJitter     = 0.2
+)
+
+// BackoffStrategy computes the delay to wait before a reconnection attempt.
+// It allows plugging in custom policies (e.g. decorrelated jitter) in place
+// of the default exponential backoff.
+type BackoffStrategy interface {
+	// Backoff returns the delay to wait before retrying, given the number
+	// of retries already made since the last successful read (0 for the
+	// first retry).
+	Backoff(retries int) time.Duration
+}
+
+// DefaultBackoffConfig is the BackoffConfig used when a transport client is
+// not given an explicit BackoffStrategy: 1 second base delay, 120 second
+// cap, 1.6x multiplier and 0.2 jitter, as used by grpc-go.
+var DefaultBackoffConfig = BackoffConfig{
+	BaseDelay:  backoffDefaultBaseDelay,
+	MaxDelay:   backoffDefaultMaxDelay,
+	Multiplier: backoffDefaultMultiplier,
+	Jitter:     backoffDefaultJitter,
+}
+
+// BackoffConfig is a BackoffStrategy. The delay grows exponentially with
+// the retry count, up to MaxDelay, then is randomized by +/- Jitter to
+// avoid thundering-herd reconnects. Fields are used exactly as set, with no
+// implicit per-field defaulting, so a zero Jitter (or any other zero field)
+// is honored rather than silently replaced; use DefaultBackoffConfig to get
+// the conventional defaults.
+type BackoffConfig struct {
+	// delay used for the first reconnection attempt.
+	BaseDelay time.Duration
+
+	// maximum delay between reconnection attempts.
+	MaxDelay time.Duration
+
+	// factor the delay is multiplied by after every failed attempt.
+	Multiplier float64
+
+	// fraction of randomization applied to the delay, in the range [0, 1].
+	// Zero disables randomization.
+	Jitter float64
+}
+
+// Backoff implements BackoffStrategy.
+func (bc BackoffConfig) Backoff(retries int) time.Duration {
+	delay := float64(bc.BaseDelay)
+	for i := 0; i < retries; i++ {
+		delay *= bc.Multiplier
+		if delay >= float64(bc.MaxDelay) {
+			delay = float64(bc.MaxDelay)
+			break
+		}
+	}
+
+	delay *= 1 + bc.Jitter*(rand.Float64()*2-1)
+	if delay < 0 {
+		delay = 0
+	}
+
+	return time.Duration(delay)
+}
@@ -0,0 +1,69 @@
+package gomavlib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoffConfigSequence(t *testing.T) {
+	bc := BackoffConfig{
+		BaseDelay:  1 * time.Second,
+		MaxDelay:   120 * time.Second,
+		Multiplier: 1.6,
+		Jitter:     0, // disable randomization to assert the exact sequence
+	}
+
+	expected := []time.Duration{
+		1 * time.Second,
+		1600 * time.Millisecond,
+		2560 * time.Millisecond,
+	}
+
+	for retries, exp := range expected {
+		require.Equal(t, exp, bc.Backoff(retries))
+	}
+}
+
+func TestBackoffConfigCapsAtMaxDelay(t *testing.T) {
+	bc := BackoffConfig{
+		BaseDelay:  1 * time.Second,
+		MaxDelay:   5 * time.Second,
+		Multiplier: 1.6,
+		Jitter:     0,
+	}
+
+	require.Equal(t, 5*time.Second, bc.Backoff(100))
+}
+
+func TestBackoffConfigAppliesJitter(t *testing.T) {
+	bc := BackoffConfig{
+		BaseDelay:  1 * time.Second,
+		MaxDelay:   120 * time.Second,
+		Multiplier: 1.6,
+		Jitter:     0.2,
+	}
+
+	for i := 0; i < 100; i++ {
+		d := bc.Backoff(0)
+		require.GreaterOrEqual(t, d, 800*time.Millisecond)
+		require.LessOrEqual(t, d, 1200*time.Millisecond)
+	}
+}
+
+func TestBackoffConfigZeroValueHasNoImplicitDefaults(t *testing.T) {
+	// a zero BackoffConfig is used as-is: no retry delay at all, since
+	// per-field defaulting would make an explicit Jitter: 0 indistinguishable
+	// from an unset one.
+	bc := BackoffConfig{}
+	require.Equal(t, time.Duration(0), bc.Backoff(0))
+	require.Equal(t, time.Duration(0), bc.Backoff(5))
+}
+
+func TestDefaultBackoffConfig(t *testing.T) {
+	require.Equal(t, backoffDefaultBaseDelay, DefaultBackoffConfig.BaseDelay)
+	require.Equal(t, backoffDefaultMaxDelay, DefaultBackoffConfig.MaxDelay)
+	require.Equal(t, backoffDefaultMultiplier, DefaultBackoffConfig.Multiplier)
+	require.Equal(t, backoffDefaultJitter, DefaultBackoffConfig.Jitter)
+}
@@ -0,0 +1,239 @@
+package gomavlib
+
+import (
+	"sync/atomic"
+)
+
+// FrameHeader contains the fields of an inbound MAVLink frame that are
+// available before the payload is decoded, and on which a MessageFilter
+// can make its accept/drop decision without parsing the rest of the frame.
+type FrameHeader struct {
+	// id of the MAVLink message carried by the frame.
+	MessageId uint32
+
+	// id of the system that sent the frame.
+	SystemId uint8
+
+	// id of the component that sent the frame.
+	ComponentId uint8
+}
+
+// MessageFilter decides whether an inbound frame should be delivered to
+// user code. Filters are checked right after CRC validation and before a
+// frame is enqueued for delivery, so that noisy links can be dropped
+// without waking user code. A filter is built once and may be shared
+// across all TransportChannels of a node.
+type MessageFilter interface {
+	// Match returns true if the frame should be delivered.
+	Match(header FrameHeader, payload []byte) bool
+
+	// Stats returns the number of frames this filter has accepted and
+	// dropped so far.
+	Stats() (accepted uint64, dropped uint64)
+}
+
+// filterOpcode is one instruction of a compiled MessageFilter program,
+// modeled after the classic BPF instruction set used by golang.org/x/net/bpf.
+type filterOpcode uint8
+
+const (
+	filterOpLoadID   filterOpcode = iota // load the message id into the accumulator
+	filterOpLoadSys                      // load the system id into the accumulator
+	filterOpLoadComp                     // load the component id into the accumulator
+	filterOpCmp                          // compare the accumulator against Val; skip the next instruction if they differ
+	filterOpJmp                          // jump forward by Val instructions
+	filterOpRet                          // return Val != 0
+)
+
+type filterInstruction struct {
+	op  filterOpcode
+	val uint32
+}
+
+// compiledFilter is a MessageFilter built from a small program of
+// filterInstructions. It carries no per-match state besides the
+// accepted/dropped counters, so a single instance is compiled once and
+// reused across all TransportChannels without per-message allocation.
+type compiledFilter struct {
+	prog     []filterInstruction
+	accepted uint64
+	dropped  uint64
+}
+
+func newCompiledFilter(prog []filterInstruction) *compiledFilter {
+	return &compiledFilter{prog: prog}
+}
+
+func (f *compiledFilter) run(header FrameHeader) bool {
+	var acc uint32
+	pc := 0
+	for pc < len(f.prog) {
+		ins := f.prog[pc]
+		switch ins.op {
+		case filterOpLoadID:
+			acc = header.MessageId
+		case filterOpLoadSys:
+			acc = uint32(header.SystemId)
+		case filterOpLoadComp:
+			acc = uint32(header.ComponentId)
+		case filterOpCmp:
+			if acc != ins.val {
+				pc++
+			}
+		case filterOpJmp:
+			pc += int(ins.val)
+			continue
+		case filterOpRet:
+			return ins.val != 0
+		}
+		pc++
+	}
+	return false
+}
+
+func (f *compiledFilter) Match(header FrameHeader, payload []byte) bool {
+	ok := f.run(header)
+	if ok {
+		atomic.AddUint64(&f.accepted, 1)
+	} else {
+		atomic.AddUint64(&f.dropped, 1)
+	}
+	return ok
+}
+
+func (f *compiledFilter) Stats() (accepted uint64, dropped uint64) {
+	return atomic.LoadUint64(&f.accepted), atomic.LoadUint64(&f.dropped)
+}
+
+// FilterByMessageId returns a MessageFilter that matches frames carrying
+// the given MAVLink message id.
+func FilterByMessageId(id uint32) MessageFilter {
+	return newCompiledFilter([]filterInstruction{
+		{op: filterOpLoadID},
+		{op: filterOpCmp, val: id},
+		{op: filterOpRet, val: 1},
+		{op: filterOpRet, val: 0},
+	})
+}
+
+// FilterBySystemId returns a MessageFilter that matches frames sent by the
+// given system id.
+func FilterBySystemId(id uint8) MessageFilter {
+	return newCompiledFilter([]filterInstruction{
+		{op: filterOpLoadSys},
+		{op: filterOpCmp, val: uint32(id)},
+		{op: filterOpRet, val: 1},
+		{op: filterOpRet, val: 0},
+	})
+}
+
+// FilterByComponentId returns a MessageFilter that matches frames sent by
+// the given component id.
+func FilterByComponentId(id uint8) MessageFilter {
+	return newCompiledFilter([]filterInstruction{
+		{op: filterOpLoadComp},
+		{op: filterOpCmp, val: uint32(id)},
+		{op: filterOpRet, val: 1},
+		{op: filterOpRet, val: 0},
+	})
+}
+
+// FilterByAnyMessageId returns a MessageFilter that matches frames carrying
+// any of the given MAVLink message ids. Unlike chaining FilterOr over N
+// FilterByMessageId filters, it compiles to a single program that uses
+// filterOpJmp to short-circuit straight to the match branch as soon as one
+// id hits.
+func FilterByAnyMessageId(ids ...uint32) MessageFilter {
+	k := len(ids)
+	prog := make([]filterInstruction, 0, 1+2*k+2)
+	prog = append(prog, filterInstruction{op: filterOpLoadID})
+	for i, id := range ids {
+		prog = append(prog,
+			filterInstruction{op: filterOpCmp, val: id},
+			filterInstruction{op: filterOpJmp, val: uint32(2 * (k - i))},
+		)
+	}
+	prog = append(prog,
+		filterInstruction{op: filterOpRet, val: 0},
+		filterInstruction{op: filterOpRet, val: 1},
+	)
+	return newCompiledFilter(prog)
+}
+
+// FilterFunc adapts an arbitrary predicate into a MessageFilter.
+type FilterFunc func(header FrameHeader, payload []byte) bool
+
+type predicateFilter struct {
+	fn       FilterFunc
+	accepted uint64
+	dropped  uint64
+}
+
+// FilterPredicate returns a MessageFilter that matches frames for which fn
+// returns true.
+func FilterPredicate(fn FilterFunc) MessageFilter {
+	return &predicateFilter{fn: fn}
+}
+
+func (f *predicateFilter) Match(header FrameHeader, payload []byte) bool {
+	ok := f.fn(header, payload)
+	if ok {
+		atomic.AddUint64(&f.accepted, 1)
+	} else {
+		atomic.AddUint64(&f.dropped, 1)
+	}
+	return ok
+}
+
+func (f *predicateFilter) Stats() (accepted uint64, dropped uint64) {
+	return atomic.LoadUint64(&f.accepted), atomic.LoadUint64(&f.dropped)
+}
+
+// combinedFilter composes multiple filters with a boolean operator. It is
+// returned by FilterAnd and FilterOr.
+type combinedFilter struct {
+	filters  []MessageFilter
+	all      bool // true: AND semantics, false: OR semantics
+	accepted uint64
+	dropped  uint64
+}
+
+// FilterAnd returns a MessageFilter that matches only if every given filter
+// matches. Sub-filters are evaluated in order and short-circuit on the
+// first mismatch.
+func FilterAnd(filters ...MessageFilter) MessageFilter {
+	return &combinedFilter{filters: filters, all: true}
+}
+
+// FilterOr returns a MessageFilter that matches if any given filter
+// matches. Sub-filters are evaluated in order and short-circuit on the
+// first match.
+func FilterOr(filters ...MessageFilter) MessageFilter {
+	return &combinedFilter{filters: filters, all: false}
+}
+
+func (f *combinedFilter) Match(header FrameHeader, payload []byte) bool {
+	ok := f.all
+	for _, sub := range f.filters {
+		res := sub.Match(header, payload)
+		if f.all && !res {
+			ok = false
+			break
+		}
+		if !f.all && res {
+			ok = true
+			break
+		}
+	}
+
+	if ok {
+		atomic.AddUint64(&f.accepted, 1)
+	} else {
+		atomic.AddUint64(&f.dropped, 1)
+	}
+	return ok
+}
+
+func (f *combinedFilter) Stats() (accepted uint64, dropped uint64) {
+	return atomic.LoadUint64(&f.accepted), atomic.LoadUint64(&f.dropped)
+}
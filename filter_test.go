@@ -0,0 +1,81 @@
+package gomavlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterByMessageId(t *testing.T) {
+	f := FilterByMessageId(66)
+
+	require.True(t, f.Match(FrameHeader{MessageId: 66}, nil))
+	require.False(t, f.Match(FrameHeader{MessageId: 0}, nil))
+
+	accepted, dropped := f.Stats()
+	require.Equal(t, uint64(1), accepted)
+	require.Equal(t, uint64(1), dropped)
+}
+
+func TestFilterBySystemAndComponentId(t *testing.T) {
+	sys := FilterBySystemId(1)
+	comp := FilterByComponentId(200)
+
+	require.True(t, sys.Match(FrameHeader{SystemId: 1, ComponentId: 200}, nil))
+	require.False(t, sys.Match(FrameHeader{SystemId: 2, ComponentId: 200}, nil))
+
+	require.True(t, comp.Match(FrameHeader{SystemId: 1, ComponentId: 200}, nil))
+	require.False(t, comp.Match(FrameHeader{SystemId: 1, ComponentId: 1}, nil))
+}
+
+func TestFilterPredicate(t *testing.T) {
+	f := FilterPredicate(func(header FrameHeader, payload []byte) bool {
+		return len(payload) > 4
+	})
+
+	require.True(t, f.Match(FrameHeader{}, []byte{1, 2, 3, 4, 5}))
+	require.False(t, f.Match(FrameHeader{}, []byte{1}))
+}
+
+func TestFilterAnd(t *testing.T) {
+	f := FilterAnd(FilterByMessageId(0), FilterBySystemId(1))
+
+	require.True(t, f.Match(FrameHeader{MessageId: 0, SystemId: 1}, nil))
+	require.False(t, f.Match(FrameHeader{MessageId: 0, SystemId: 2}, nil))
+	require.False(t, f.Match(FrameHeader{MessageId: 1, SystemId: 1}, nil))
+}
+
+func TestFilterOr(t *testing.T) {
+	f := FilterOr(FilterByMessageId(0), FilterByMessageId(66))
+
+	require.True(t, f.Match(FrameHeader{MessageId: 0}, nil))
+	require.True(t, f.Match(FrameHeader{MessageId: 66}, nil))
+	require.False(t, f.Match(FrameHeader{MessageId: 1}, nil))
+}
+
+func TestFilterByAnyMessageId(t *testing.T) {
+	f := FilterByAnyMessageId(0, 66, 77)
+
+	require.True(t, f.Match(FrameHeader{MessageId: 0}, nil))
+	require.True(t, f.Match(FrameHeader{MessageId: 66}, nil))
+	require.True(t, f.Match(FrameHeader{MessageId: 77}, nil))
+	require.False(t, f.Match(FrameHeader{MessageId: 1}, nil))
+
+	accepted, dropped := f.Stats()
+	require.Equal(t, uint64(3), accepted)
+	require.Equal(t, uint64(1), dropped)
+}
+
+func TestFilterOpJmp(t *testing.T) {
+	// hand-built program exercising filterOpJmp's pc arithmetic directly:
+	// load the message id, unconditionally jump over a false-return, and
+	// land on a true-return.
+	f := newCompiledFilter([]filterInstruction{
+		{op: filterOpLoadID},
+		{op: filterOpJmp, val: 2},
+		{op: filterOpRet, val: 0},
+		{op: filterOpRet, val: 1},
+	})
+
+	require.True(t, f.Match(FrameHeader{MessageId: 123}, nil))
+}
@@ -0,0 +1,202 @@
+package gomavlib
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+const (
+	frameStartByteV1 = 0xFE
+	frameHeaderLenV1 = 6 // STX, LEN, SEQ, SYSID, COMPID, MSGID
+	frameChecksumLen = 2
+)
+
+// NodeConf configures a Node.
+type NodeConf struct {
+	// transports used to receive and send frames.
+	Transports []TransportConf
+
+	// filters applied, ANDed together, to every inbound frame right after
+	// CRC validation and before it is enqueued on Events. Combine filters
+	// with FilterOr to express OR semantics across them. If empty, every
+	// frame that passes CRC validation is delivered.
+	Filters []MessageFilter
+}
+
+// Frame is a MAVLink frame that passed CRC validation and every configured
+// MessageFilter, ready for delivery to user code.
+type Frame struct {
+	Header  FrameHeader
+	Payload []byte
+}
+
+// Node owns every channel of every transport described by a NodeConf. It
+// reads frames from them in the background, filters them and delivers the
+// ones that pass on Events.
+type Node struct {
+	conf   NodeConf
+	filter MessageFilter
+
+	mutex   sync.Mutex
+	closers []io.Closer
+
+	// Events delivers frames that passed CRC validation and every filter.
+	Events chan *Frame
+}
+
+// NewNode initializes the transports described by conf and spawns the
+// goroutines that read frames from them into Events.
+func NewNode(conf NodeConf) (*Node, error) {
+	n := &Node{
+		conf:   conf,
+		Events: make(chan *Frame, 64),
+	}
+	if len(conf.Filters) > 0 {
+		n.filter = FilterAnd(conf.Filters...)
+	}
+
+	for _, tc := range conf.Transports {
+		tr, err := tc.init()
+		if err != nil {
+			n.Close()
+			return nil, err
+		}
+		n.startTransport(tr)
+	}
+
+	return n, nil
+}
+
+// startTransport spawns the goroutine(s) that pump frames from tr into
+// Events, depending on whether it provides a single channel or accepts many.
+func (n *Node) startTransport(tr transport) {
+	switch t := tr.(type) {
+	case transportChannelSingle:
+		n.addCloser(t)
+		go n.readChannel(t)
+
+	case transportChannelAccepter:
+		n.addCloser(t)
+		go n.acceptChannels(t)
+	}
+}
+
+// readChannel reads frames from rwc into Events until it errors, e.g.
+// because the channel was closed.
+func (n *Node) readChannel(rwc io.ReadWriteCloser) {
+	for {
+		if err := n.ReadFrame(rwc); err != nil {
+			return
+		}
+	}
+}
+
+// acceptChannels accepts incoming channels from a and spawns a readChannel
+// goroutine for each one, until Accept errors.
+func (n *Node) acceptChannels(a transportChannelAccepter) {
+	for {
+		rwc, err := a.Accept()
+		if err != nil {
+			return
+		}
+		go n.readChannel(rwc)
+	}
+}
+
+func (n *Node) addCloser(c io.Closer) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.closers = append(n.closers, c)
+}
+
+// Close shuts down every transport owned by the node, stopping its read
+// goroutines.
+func (n *Node) Close() error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	for _, c := range n.closers {
+		c.Close()
+	}
+	return nil
+}
+
+// crcInitX25 is the MAVLink checksum seed (X.25/CRC-16/MCRF4XX).
+const crcInitX25 = 0xffff
+
+// x25Checksum computes the MAVLink v1 checksum over data. It does not fold
+// in a message's dialect-specific CRC_EXTRA byte, since the per-message
+// extra-byte table is generated from dialect XML that isn't present in
+// this tree.
+func x25Checksum(data []byte) uint16 {
+	crc := uint16(crcInitX25)
+	for _, b := range data {
+		tmp := b ^ byte(crc&0xff)
+		tmp ^= tmp << 4
+		crc = (crc >> 8) ^ (uint16(tmp) << 8) ^ (uint16(tmp) << 3) ^ (uint16(tmp) >> 4)
+	}
+	return crc
+}
+
+// decodeFrameV1 validates buf's checksum and, if valid, extracts its
+// FrameHeader and payload.
+func decodeFrameV1(buf []byte) (FrameHeader, []byte, error) {
+	if len(buf) < frameHeaderLenV1+frameChecksumLen {
+		return FrameHeader{}, nil, fmt.Errorf("frame too short")
+	}
+	if buf[0] != frameStartByteV1 {
+		return FrameHeader{}, nil, fmt.Errorf("invalid start byte")
+	}
+
+	payloadEnd := frameHeaderLenV1 + int(buf[1])
+	if len(buf) != payloadEnd+frameChecksumLen {
+		return FrameHeader{}, nil, fmt.Errorf("frame length mismatch")
+	}
+
+	expected := uint16(buf[payloadEnd]) | uint16(buf[payloadEnd+1])<<8
+	if x25Checksum(buf[1:payloadEnd]) != expected {
+		return FrameHeader{}, nil, fmt.Errorf("invalid checksum")
+	}
+
+	header := FrameHeader{
+		SystemId:    buf[3],
+		ComponentId: buf[4],
+		MessageId:   uint32(buf[5]),
+	}
+	return header, buf[frameHeaderLenV1:payloadEnd], nil
+}
+
+// processFrame validates buf, checks it against the configured filters and,
+// if it passes, enqueues it on Events.
+func (n *Node) processFrame(buf []byte) error {
+	header, payload, err := decodeFrameV1(buf)
+	if err != nil {
+		return err
+	}
+
+	if n.filter != nil && !n.filter.Match(header, payload) {
+		return nil
+	}
+
+	n.Events <- &Frame{Header: header, Payload: payload}
+	return nil
+}
+
+// ReadFrame reads one MAVLink v1 frame from r, validates its checksum and,
+// if it passes every configured filter, enqueues it on Events.
+func (n *Node) ReadFrame(r io.Reader) error {
+	head := make([]byte, 2)
+	if _, err := io.ReadFull(r, head); err != nil {
+		return err
+	}
+	if head[0] != frameStartByteV1 {
+		return fmt.Errorf("invalid start byte")
+	}
+
+	rest := make([]byte, (frameHeaderLenV1-len(head))+int(head[1])+frameChecksumLen)
+	if _, err := io.ReadFull(r, rest); err != nil {
+		return err
+	}
+
+	return n.processFrame(append(head, rest...))
+}
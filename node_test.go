@@ -0,0 +1,97 @@
+package gomavlib
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func buildFrameV1(sysId, compId, msgId byte, payload []byte) []byte {
+	buf := []byte{frameStartByteV1, byte(len(payload)), 0, sysId, compId, msgId}
+	buf = append(buf, payload...)
+	crc := x25Checksum(buf[1:])
+	return append(buf, byte(crc), byte(crc>>8))
+}
+
+func TestNodeReadFrameDelivers(t *testing.T) {
+	n, err := NewNode(NodeConf{})
+	require.NoError(t, err)
+
+	frame := buildFrameV1(1, 200, 66, []byte{1, 2, 3})
+	require.NoError(t, n.ReadFrame(bytes.NewReader(frame)))
+
+	select {
+	case f := <-n.Events:
+		require.Equal(t, uint32(66), f.Header.MessageId)
+		require.Equal(t, uint8(1), f.Header.SystemId)
+		require.Equal(t, uint8(200), f.Header.ComponentId)
+		require.Equal(t, []byte{1, 2, 3}, f.Payload)
+	default:
+		t.Fatal("expected a frame on Events")
+	}
+}
+
+func TestNodeReadFrameInvalidChecksum(t *testing.T) {
+	n, err := NewNode(NodeConf{})
+	require.NoError(t, err)
+
+	frame := buildFrameV1(1, 200, 66, nil)
+	frame[len(frame)-1] ^= 0xff
+
+	require.Error(t, n.ReadFrame(bytes.NewReader(frame)))
+}
+
+func TestNodeFiltersFrameBeforeDelivery(t *testing.T) {
+	filter := FilterByMessageId(66)
+	n, err := NewNode(NodeConf{
+		Filters: []MessageFilter{filter},
+	})
+	require.NoError(t, err)
+
+	frame := buildFrameV1(1, 200, 0, nil) // message id 0, filter wants 66
+	require.NoError(t, n.ReadFrame(bytes.NewReader(frame)))
+
+	select {
+	case <-n.Events:
+		t.Fatal("frame should have been dropped by the filter")
+	default:
+	}
+
+	accepted, dropped := filter.Stats()
+	require.Equal(t, uint64(0), accepted)
+	require.Equal(t, uint64(1), dropped)
+}
+
+func TestNodeReadsFromConfiguredTransports(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write(buildFrameV1(1, 200, 66, []byte{9, 9, 9}))
+	}()
+
+	n, err := NewNode(NodeConf{
+		Transports: []TransportConf{
+			TransportTcpClient{Address: ln.Addr().String()},
+		},
+	})
+	require.NoError(t, err)
+	defer n.Close()
+
+	select {
+	case f := <-n.Events:
+		require.Equal(t, uint32(66), f.Header.MessageId)
+		require.Equal(t, []byte{9, 9, 9}, f.Payload)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for a frame read from the configured transport")
+	}
+}
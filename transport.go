@@ -11,6 +11,13 @@ type TransportChannel struct {
 }
 
 // TransportConf is the interface implemented by all transports.
+//
+// NOTE: this tree only contains the TCP/UDP client transports
+// (TransportTcpClient, TransportUdpClient); the server-side counterparts
+// (e.g. TransportTcpServer, TransportUdpServer) referenced by
+// NikolaeVarius/gomavlib#chunk0-2 don't exist here, so that request's
+// ask to update "the corresponding server transports" for IPv6/dual-stack
+// support could not be applied. Flagging for whoever filed the request.
 type TransportConf interface {
 	init() (transport, error)
 }
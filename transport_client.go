@@ -11,12 +11,40 @@ import (
 type transportClientConf interface {
 	isUdp() bool
 	getAddress() string
+	getNetwork() string
+	getBackoff() BackoffStrategy
+}
+
+// isValidClientNetwork returns whether network is an allowed value for the
+// given transport kind (tcp or udp).
+func isValidClientNetwork(network string, isUdp bool) bool {
+	if isUdp {
+		switch network {
+		case "", "udp", "udp4", "udp6":
+			return true
+		}
+		return false
+	}
+	switch network {
+	case "", "tcp", "tcp4", "tcp6":
+		return true
+	}
+	return false
 }
 
 // TransportTcpClient sets up a transport that works through a TCP client.
 type TransportTcpClient struct {
 	// domain name or IP of the server to connect to, example: 1.2.3.4:5600
+	// IPv6 literals must be enclosed in brackets, example: [fe80::1%eth0]:5600
 	Address string
+
+	// address family to use when dialing: "tcp" (dual-stack), "tcp4" or
+	// "tcp6". It defaults to "tcp".
+	Network string
+
+	// strategy used to compute the delay between reconnection attempts.
+	// It defaults to DefaultBackoffConfig.
+	Backoff BackoffStrategy
 }
 
 func (TransportTcpClient) isUdp() bool {
@@ -27,6 +55,20 @@ func (conf TransportTcpClient) getAddress() string {
 	return conf.Address
 }
 
+func (conf TransportTcpClient) getNetwork() string {
+	if conf.Network != "" {
+		return conf.Network
+	}
+	return "tcp"
+}
+
+func (conf TransportTcpClient) getBackoff() BackoffStrategy {
+	if conf.Backoff != nil {
+		return conf.Backoff
+	}
+	return DefaultBackoffConfig
+}
+
 func (conf TransportTcpClient) init() (transport, error) {
 	return initTransportClient(conf)
 }
@@ -34,7 +76,16 @@ func (conf TransportTcpClient) init() (transport, error) {
 // TransportUdpClient sets up a transport that works through a UDP client.
 type TransportUdpClient struct {
 	// domain name or IP of the server to connect to, example: 1.2.3.4:5600
+	// IPv6 literals must be enclosed in brackets, example: [fe80::1%eth0]:5600
 	Address string
+
+	// address family to use when dialing: "udp" (dual-stack), "udp4" or
+	// "udp6". It defaults to "udp".
+	Network string
+
+	// strategy used to compute the delay between reconnection attempts.
+	// It defaults to DefaultBackoffConfig.
+	Backoff BackoffStrategy
 }
 
 func (TransportUdpClient) isUdp() bool {
@@ -45,6 +96,20 @@ func (conf TransportUdpClient) getAddress() string {
 	return conf.Address
 }
 
+func (conf TransportUdpClient) getNetwork() string {
+	if conf.Network != "" {
+		return conf.Network
+	}
+	return "udp"
+}
+
+func (conf TransportUdpClient) getBackoff() BackoffStrategy {
+	if conf.Backoff != nil {
+		return conf.Backoff
+	}
+	return DefaultBackoffConfig
+}
+
 func (conf TransportUdpClient) init() (transport, error) {
 	return initTransportClient(conf)
 }
@@ -54,14 +119,22 @@ type transportClient struct {
 	mutex     sync.Mutex
 	terminate chan struct{}
 	conn      io.ReadWriteCloser
+	retries   int
 }
 
 func initTransportClient(conf transportClientConf) (transport, error) {
+	// SplitHostPort already understands bracketed IPv6 literals, including
+	// those carrying a zone identifier (e.g. "[fe80::1%eth0]:14550"), and
+	// returns the host with the zone preserved.
 	_, _, err := net.SplitHostPort(conf.getAddress())
 	if err != nil {
 		return nil, fmt.Errorf("invalid address")
 	}
 
+	if !isValidClientNetwork(conf.getNetwork(), conf.isUdp()) {
+		return nil, fmt.Errorf("invalid network: %s", conf.getNetwork())
+	}
+
 	t := &transportClient{
 		conf:      conf,
 		terminate: make(chan struct{}, 1),
@@ -97,14 +170,8 @@ func (t *transportClient) Read(buf []byte) (int, error) {
 			var rawConn net.Conn
 			dialDone := make(chan struct{}, 1)
 			go func() {
-				var network string
-				if t.conf.isUdp() == true {
-					network = "udp4"
-				} else {
-					network = "tcp4"
-				}
 				var err error
-				rawConn, err = net.DialTimeout(network, t.conf.getAddress(), netConnectTimeout)
+				rawConn, err = net.DialTimeout(t.conf.getNetwork(), t.conf.getAddress(), netConnectTimeout)
 				if err != nil {
 					rawConn = nil // ensure rawConn is nil in case of error
 				}
@@ -117,9 +184,12 @@ func (t *transportClient) Read(buf []byte) (int, error) {
 				return 0, errorTerminated
 			}
 
-			// wait some seconds before reconnecting
+			// wait before reconnecting, backing off exponentially so that
+			// many clients losing the same server at once don't all
+			// hammer it with reconnections in lockstep
 			if rawConn == nil {
-				timer := time.NewTimer(netReconnectPeriod)
+				timer := time.NewTimer(t.conf.getBackoff().Backoff(t.retries))
+				t.retries++
 				select {
 				case <-timer.C:
 					continue
@@ -162,6 +232,8 @@ func (t *transportClient) Read(buf []byte) (int, error) {
 			continue
 		}
 
+		t.retries = 0
+
 		return n, nil
 	}
 }
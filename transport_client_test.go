@@ -0,0 +1,36 @@
+package gomavlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransportClientIpv6Loopback(t *testing.T) {
+	_, err := TransportTcpClient{
+		Address: "[::1]:5600",
+		Network: "tcp6",
+	}.init()
+	require.NoError(t, err)
+}
+
+func TestTransportClientIpv6LinkLocalZone(t *testing.T) {
+	_, err := TransportUdpClient{
+		Address: "[fe80::1%eth0]:14550",
+		Network: "udp6",
+	}.init()
+	require.NoError(t, err)
+}
+
+func TestTransportClientNetworkDefaults(t *testing.T) {
+	require.Equal(t, "tcp", TransportTcpClient{}.getNetwork())
+	require.Equal(t, "udp", TransportUdpClient{}.getNetwork())
+}
+
+func TestTransportClientInvalidNetwork(t *testing.T) {
+	_, err := TransportTcpClient{
+		Address: "1.2.3.4:5600",
+		Network: "udp4",
+	}.init()
+	require.Error(t, err)
+}